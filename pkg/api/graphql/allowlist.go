@@ -0,0 +1,112 @@
+// Package graphql exposes a GraphQL facade over dashboards, folders, panels
+// and datasources. It intentionally has no data access of its own: every
+// resolver delegates to an existing service (searchV2.SearchService,
+// folder.Service, ...) so GraphQL stays a thin, auditable read path rather
+// than a second source of truth.
+package graphql
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+// AllowList implements the persisted-query allow-list pattern: in
+// production only queries whose SHA256 hash already appears in the
+// checked-in list file may execute. In dev mode, queries are hashed and
+// appended to the file the first time they are seen, so the list can be
+// grown locally and committed alongside the queries that use them.
+type AllowList struct {
+	mu      sync.Mutex
+	path    string
+	devMode bool
+	hashes  map[string]struct{}
+}
+
+// NewAllowList loads path and returns the AllowList built from its
+// contents. A missing file is an error unless devMode is true; the list
+// then starts empty and the file is created on the first append.
+func NewAllowList(path string, devMode bool) (*AllowList, error) {
+	al := &AllowList{path: path, devMode: devMode, hashes: map[string]struct{}{}}
+
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		if !devMode {
+			return nil, fmt.Errorf("graphql: allow-list %q does not exist", path)
+		}
+		return al, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = f.Close() }()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		// Only the hash before " # <first line>" is the key.
+		if i := strings.IndexAny(line, " \t#"); i >= 0 {
+			line = line[:i]
+		}
+		al.hashes[line] = struct{}{}
+	}
+	return al, scanner.Err()
+}
+
+// HashQuery returns the allow-list key for a query string.
+func HashQuery(query string) string {
+	sum := sha256.Sum256([]byte(query))
+	return hex.EncodeToString(sum[:])
+}
+
+// Allow reports whether query may execute. In dev mode, a query not yet in
+// the list is appended to it (with its hash as the key) and allowed; in
+// production an unknown query is rejected.
+func (al *AllowList) Allow(query string) (bool, error) {
+	hash := HashQuery(query)
+
+	al.mu.Lock()
+	defer al.mu.Unlock()
+
+	if _, ok := al.hashes[hash]; ok {
+		return true, nil
+	}
+	if !al.devMode {
+		return false, nil
+	}
+
+	if err := al.appendLocked(hash, query); err != nil {
+		return false, err
+	}
+	al.hashes[hash] = struct{}{}
+	return true, nil
+}
+
+// appendLocked writes hash and the first line of query (for reviewability)
+// to the allow-list file. Callers must hold al.mu.
+func (al *AllowList) appendLocked(hash, query string) error {
+	f, err := os.OpenFile(al.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+
+	_, err = fmt.Fprintf(f, "%s # %s\n", hash, firstLine(query))
+	return err
+}
+
+func firstLine(s string) string {
+	for i, r := range s {
+		if r == '\n' {
+			return s[:i]
+		}
+	}
+	return s
+}
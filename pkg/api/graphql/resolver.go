@@ -0,0 +1,219 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+
+	"github.com/grafana/grafana/pkg/infra/appcontext"
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/folder"
+	"github.com/grafana/grafana/pkg/services/searchV2"
+	"github.com/grafana/grafana/pkg/services/user"
+)
+
+// Resolver implements the root Query type, backed by search and
+// folderSvc. Each field resolver also re-checks accesscontrol permissions
+// before calling into its service, since the persisted-query allow-list
+// only constrains which query *shapes* may run, not which rows a given
+// caller may see.
+type Resolver struct {
+	search    searchV2.SearchService
+	folderSvc folder.Service
+}
+
+// NewResolver builds a Resolver backed by the given services.
+func NewResolver(search searchV2.SearchService, folderSvc folder.Service) *Resolver {
+	return &Resolver{search: search, folderSvc: folderSvc}
+}
+
+// searchArgs mirrors the subset of searchV2.DashboardQuery exposed over
+// GraphQL.
+type searchArgs struct {
+	Query *string
+	Kind  *[]string
+	Tags  *[]string
+	Limit *int32
+}
+
+// searchResult adapts backend.DataResponse to the SearchResult GraphQL
+// type; graph-gophers resolves fields by method/field name via reflection.
+type searchResult struct {
+	resp *backend.DataResponse
+}
+
+func (s *searchResult) TotalHits() int32 {
+	if s.resp == nil || len(s.resp.Frames) == 0 {
+		return 0
+	}
+	return int32(s.resp.Frames[0].Rows())
+}
+
+// Search resolves the `search` root field by delegating to
+// SearchService.DoDashboardQuery, so GraphQL search results are always
+// consistent with the dashboard search page and its SQL-level RBAC
+// filtering.
+func (r *Resolver) Search(ctx context.Context, args searchArgs) (*searchResult, error) {
+	signedInUser, err := userFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	allowed, err := fieldAllowed(signedInUser, "dashboard.uid", "dashboards:uid:", accesscontrol.ActionDashboardsRead)
+	if err != nil {
+		return nil, err
+	}
+	if !allowed {
+		return nil, errFieldNotAllowed
+	}
+
+	query := searchV2.DashboardQuery{}
+	if args.Query != nil {
+		query.Query = *args.Query
+	}
+	if args.Kind != nil {
+		query.Kind = *args.Kind
+	}
+	if args.Tags != nil {
+		query.Tags = *args.Tags
+	}
+	if args.Limit != nil {
+		query.Limit = int(*args.Limit)
+	}
+
+	beUser := &backend.User{Login: signedInUser.Login, Email: signedInUser.Email}
+	resp := r.search.DoDashboardQuery(ctx, beUser, signedInUser.OrgID, query)
+	return &searchResult{resp: filterRowsByScope(signedInUser, resp)}, nil
+}
+
+// filterRowsByScope trims resp's frame down to the rows signedInUser is
+// actually allowed to see. fieldAllowed only gates whether the user may call
+// search at all, i.e. whether they hold dashboards:read on *some* resource;
+// a non-wildcard scope still must not see other dashboards' rows, so every
+// returned uid is re-checked individually via scopeAllowed, the same check
+// the single-resource `folder(uid: ...)` field already relies on.
+func filterRowsByScope(signedInUser *user.SignedInUser, resp *backend.DataResponse) *backend.DataResponse {
+	if resp == nil || len(resp.Frames) == 0 {
+		return resp
+	}
+	frame := resp.Frames[0]
+
+	var uidField *data.Field
+	for _, field := range frame.Fields {
+		if field.Name == "uid" {
+			uidField = field
+			break
+		}
+	}
+	if uidField == nil {
+		return resp
+	}
+
+	keep := make([]int, 0, uidField.Len())
+	for i := 0; i < uidField.Len(); i++ {
+		uid, _ := uidField.At(i).(string)
+		if scopeAllowed(signedInUser, accesscontrol.ActionDashboardsRead, "dashboards:uid:", uid) {
+			keep = append(keep, i)
+		}
+	}
+	if len(keep) == uidField.Len() {
+		return resp
+	}
+
+	filtered := data.NewFrame(frame.Name)
+	for _, field := range frame.Fields {
+		kept := make([]string, len(keep))
+		for j, i := range keep {
+			v, _ := field.At(i).(string)
+			kept[j] = v
+		}
+		filtered.Fields = append(filtered.Fields, data.NewField(field.Name, nil, kept))
+	}
+	return &backend.DataResponse{Frames: data.Frames{filtered}}
+}
+
+// folderArgs identifies the folder the `folder` field fetches.
+type folderArgs struct {
+	UID string
+}
+
+// folderResult adapts folder.Folder to the Folder GraphQL type.
+type folderResult struct {
+	f *folder.Folder
+}
+
+func (f *folderResult) UID() string { return f.f.UID }
+func (f *folderResult) Title() string { return f.f.Title }
+func (f *folderResult) ParentUID() *string {
+	if f.f.ParentUID == "" {
+		return nil
+	}
+	return &f.f.ParentUID
+}
+
+// Folder resolves the `folder` root field with a single folder.Service Get.
+// Only the folder itself (uid, title, parentUID) is returned; its parents
+// and children are not resolved.
+func (r *Resolver) Folder(ctx context.Context, args folderArgs) (*folderResult, error) {
+	signedInUser, err := userFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if !scopeAllowed(signedInUser, accesscontrol.ActionFoldersRead, "folders:uid:", args.UID) {
+		return nil, errFieldNotAllowed
+	}
+
+	f, err := r.folderSvc.Get(ctx, &folder.GetFolderQuery{UID: &args.UID, OrgID: signedInUser.OrgID, SignedInUser: signedInUser})
+	if err != nil {
+		return nil, err
+	}
+	return &folderResult{f: f}, nil
+}
+
+// errFieldNotAllowed is returned by a resolver when the signed-in user's
+// accesscontrol permissions don't cover the field being resolved. It
+// deliberately says nothing about why, matching how the REST APIs respond
+// to a forbidden request without leaking which scopes would have worked.
+var errFieldNotAllowed = errors.New("graphql: field not allowed")
+
+// fieldAllowed runs accesscontrol.Filter for sqlID/prefix/actions and
+// reports whether the resulting WHERE clause could ever match a row,
+// i.e. whether the signed-in user holds at least one of actions on at
+// least one resource under prefix. It's used to gate list-shaped fields
+// (like `search`) before SearchService is even asked to run the query.
+func fieldAllowed(signedInUser *user.SignedInUser, sqlID, prefix string, actions ...string) (bool, error) {
+	filter, err := accesscontrol.Filter(signedInUser, sqlID, prefix, actions...)
+	if err != nil {
+		return false, err
+	}
+	// A filter that can never match (see accesscontrol.Filter's denyQuery)
+	// means the user has none of the required actions.
+	return filter.Where != " 1 = 0", nil
+}
+
+// scopeAllowed reports whether signedInUser's permissions for action grant
+// access to uid under prefix, via accesscontrol.ParseScopes. It's used to
+// gate single-resource fields (like `folder(uid: ...)`) where Filter's
+// bulk WHERE-clause shape doesn't apply.
+func scopeAllowed(signedInUser *user.SignedInUser, action, prefix, uid string) bool {
+	if signedInUser == nil || signedInUser.Permissions == nil {
+		return false
+	}
+	ids, hasWildcard := accesscontrol.ParseScopes(prefix, signedInUser.Permissions[signedInUser.OrgID][action])
+	if hasWildcard {
+		return true
+	}
+	_, ok := ids[uid]
+	return ok
+}
+
+func userFromContext(ctx context.Context) (*user.SignedInUser, error) {
+	signedInUser, err := appcontext.User(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return signedInUser, nil
+}
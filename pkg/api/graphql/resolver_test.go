@@ -0,0 +1,126 @@
+package graphql
+
+import (
+	"testing"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+
+	"github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/user"
+)
+
+func TestScopeAllowed(t *testing.T) {
+	signedInUser := &user.SignedInUser{
+		OrgID: 1,
+		Permissions: map[int64]map[string][]string{
+			1: {"folders:read": {"folders:uid:abc"}},
+		},
+	}
+
+	if !scopeAllowed(signedInUser, "folders:read", "folders:uid:", "abc") {
+		t.Fatal("expected access to folder abc to be allowed")
+	}
+	if scopeAllowed(signedInUser, "folders:read", "folders:uid:", "other") {
+		t.Fatal("expected access to folder other to be denied")
+	}
+	if scopeAllowed(signedInUser, "folders:write", "folders:uid:", "abc") {
+		t.Fatal("expected access with a different action to be denied")
+	}
+}
+
+func TestScopeAllowed_NoPermissions(t *testing.T) {
+	if scopeAllowed(&user.SignedInUser{}, "folders:read", "folders:uid:", "abc") {
+		t.Fatal("expected a user with no permissions to be denied")
+	}
+	if scopeAllowed(nil, "folders:read", "folders:uid:", "abc") {
+		t.Fatal("expected a nil user to be denied")
+	}
+}
+
+func TestFieldAllowed_DeniesWithoutMatchingPermission(t *testing.T) {
+	defer accesscontrol.SetAcceptListForTest(map[string]struct{}{"dashboard.uid": {}})()
+
+	signedInUser := &user.SignedInUser{
+		OrgID:       1,
+		Permissions: map[int64]map[string][]string{1: {}},
+	}
+
+	allowed, err := fieldAllowed(signedInUser, "dashboard.uid", "dashboards:uid:", accesscontrol.ActionDashboardsRead)
+	if err != nil {
+		t.Fatalf("fieldAllowed returned error: %v", err)
+	}
+	if allowed {
+		t.Fatal("expected a user with no dashboard permissions to be denied")
+	}
+}
+
+func TestFieldAllowed_AllowsWithWildcard(t *testing.T) {
+	defer accesscontrol.SetAcceptListForTest(map[string]struct{}{"dashboard.uid": {}})()
+
+	signedInUser := &user.SignedInUser{
+		OrgID: 1,
+		Permissions: map[int64]map[string][]string{
+			1: {accesscontrol.ActionDashboardsRead: {"dashboards:uid:*"}},
+		},
+	}
+
+	allowed, err := fieldAllowed(signedInUser, "dashboard.uid", "dashboards:uid:", accesscontrol.ActionDashboardsRead)
+	if err != nil {
+		t.Fatalf("fieldAllowed returned error: %v", err)
+	}
+	if !allowed {
+		t.Fatal("expected a user with a wildcard dashboard scope to be allowed")
+	}
+}
+
+// TestFilterRowsByScope_NonWildcardScopeIsRowRestricted guards against
+// fieldAllowed's yes/no gate being mistaken for row-level authorization:
+// a user scoped to a single dashboard uid must still only see that uid's
+// row, even though fieldAllowed already let the search field resolve.
+func TestFilterRowsByScope_NonWildcardScopeIsRowRestricted(t *testing.T) {
+	signedInUser := &user.SignedInUser{
+		OrgID: 1,
+		Permissions: map[int64]map[string][]string{
+			1: {accesscontrol.ActionDashboardsRead: {"dashboards:uid:abc"}},
+		},
+	}
+
+	frame := data.NewFrame("",
+		data.NewField("uid", nil, []string{"abc", "other"}),
+		data.NewField("title", nil, []string{"Prod overview", "Secret project"}),
+	)
+	resp := &backend.DataResponse{Frames: data.Frames{frame}}
+
+	filtered := filterRowsByScope(signedInUser, resp)
+	if len(filtered.Frames) != 1 {
+		t.Fatalf("expected a single frame, got %d", len(filtered.Frames))
+	}
+
+	uidField := filtered.Frames[0].Fields[0]
+	if uidField.Len() != 1 {
+		t.Fatalf("expected 1 row to survive filtering, got %d", uidField.Len())
+	}
+	if uid, _ := uidField.At(0).(string); uid != "abc" {
+		t.Fatalf("expected the surviving row to be uid %q, got %q", "abc", uid)
+	}
+}
+
+// TestFilterRowsByScope_WildcardScopeIsUnrestricted guards against
+// over-filtering: a wildcard scope must still see every row.
+func TestFilterRowsByScope_WildcardScopeIsUnrestricted(t *testing.T) {
+	signedInUser := &user.SignedInUser{
+		OrgID: 1,
+		Permissions: map[int64]map[string][]string{
+			1: {accesscontrol.ActionDashboardsRead: {"dashboards:uid:*"}},
+		},
+	}
+
+	frame := data.NewFrame("", data.NewField("uid", nil, []string{"abc", "other"}))
+	resp := &backend.DataResponse{Frames: data.Frames{frame}}
+
+	filtered := filterRowsByScope(signedInUser, resp)
+	if filtered.Frames[0].Fields[0].Len() != 2 {
+		t.Fatalf("expected both rows to survive filtering, got %d", filtered.Frames[0].Fields[0].Len())
+	}
+}
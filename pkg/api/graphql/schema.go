@@ -0,0 +1,25 @@
+package graphql
+
+// schemaSource is the root GraphQL schema. A new query field needs a
+// matching resolver method and args struct on Resolver before it does
+// anything, so the schema can't drift ahead of what's actually wired up.
+const schemaSource = `
+	schema {
+		query: Query
+	}
+
+	type Query {
+		search(query: String, kind: [String!], tags: [String!], limit: Int): SearchResult!
+		folder(uid: String!): Folder
+	}
+
+	type SearchResult {
+		totalHits: Int!
+	}
+
+	type Folder {
+		uid: String!
+		title: String!
+		parentUID: String
+	}
+`
@@ -0,0 +1,28 @@
+package commands
+
+import (
+	"github.com/urfave/cli/v2"
+
+	"github.com/grafana/grafana/pkg/cmd/grafana-cli/runner"
+)
+
+// ServiceAccountsCommands returns the `grafana-cli service-accounts ...`
+// command, for the App.Commands table that grafana-cli's main command
+// builds up alongside plugins/admin/etc.
+func ServiceAccountsCommands(r runner.Runner) *cli.Command {
+	return &cli.Command{
+		Name:  "service-accounts",
+		Usage: "Manage service accounts and their tokens",
+		Subcommands: []*cli.Command{
+			{
+				Name:      "analyze",
+				Usage:     "Report every resource and action a service-account token or API key grants",
+				ArgsUsage: "<token>",
+				Flags:     analyzeServiceAccountTokenFlags,
+				Action: func(c *cli.Context) error {
+					return analyzeServiceAccountTokenCommand(c, r)
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,25 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/grafana/grafana/pkg/cmd/grafana-cli/runner"
+)
+
+// TestServiceAccountsCommands checks the shape App.Commands needs (a
+// top-level "service-accounts" command with an "analyze" subcommand that
+// has an Action) rather than just that the constructor compiles. It does
+// not check that the command is registered anywhere.
+func TestServiceAccountsCommands(t *testing.T) {
+	cmd := ServiceAccountsCommands(runner.Runner{})
+
+	if cmd.Name != "service-accounts" {
+		t.Fatalf("expected command name %q, got %q", "service-accounts", cmd.Name)
+	}
+	if len(cmd.Subcommands) != 1 || cmd.Subcommands[0].Name != "analyze" {
+		t.Fatalf("expected a single %q subcommand, got %#v", "analyze", cmd.Subcommands)
+	}
+	if cmd.Subcommands[0].Action == nil {
+		t.Fatal("expected the analyze subcommand to have an Action")
+	}
+}
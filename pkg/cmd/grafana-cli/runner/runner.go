@@ -0,0 +1,22 @@
+// Package runner bundles the services a grafana-cli command needs behind
+// a single Runner value, so commands depend on Runner instead of each
+// constructing its own services from config.
+package runner
+
+import (
+	"github.com/grafana/grafana/pkg/services/searchV2"
+	"github.com/grafana/grafana/pkg/services/serviceaccounts/analyzer"
+)
+
+// Config is the subset of setting.Cfg a grafana-cli command may need.
+type Config interface {
+	DefaultOrgID() int64
+}
+
+// Runner holds the services a command's Action func is handed at
+// invocation time.
+type Runner struct {
+	Cfg                       Config
+	ServiceAccountTokenLookup analyzer.TokenPermissionsLookup
+	SearchService             searchV2.SearchService
+}
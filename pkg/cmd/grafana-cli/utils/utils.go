@@ -0,0 +1,13 @@
+// Package utils narrows the grafana-cli commands' dependency on
+// urfave/cli down to the handful of methods they actually call, so
+// command handlers can be unit-tested against a fake CommandLine instead
+// of a real *cli.Context.
+package utils
+
+import "github.com/urfave/cli/v2"
+
+// CommandLine is the subset of *cli.Context a command handler needs.
+type CommandLine interface {
+	Args() cli.Args
+	Bool(name string) bool
+}
@@ -0,0 +1,47 @@
+package folders
+
+import (
+	"reflect"
+
+	common "k8s.io/kube-openapi/pkg/common"
+
+	"github.com/grafana/grafana/pkg/apis/folder/v0alpha1"
+)
+
+// withSSAExtensions wraps defs with the OpenAPI schema extensions
+// server-side-apply needs on v0alpha1.Folder: x-kubernetes-patch-strategy
+// on spec, so a three-way merge-patch knows to merge rather than replace
+// it wholesale. v0alpha1.Folder has no list-typed field yet, so there is
+// no x-kubernetes-list-type extension to add here - add one alongside
+// whichever field needs it if Folder ever grows one.
+func withSSAExtensions(defs common.GetOpenAPIDefinitions) common.GetOpenAPIDefinitions {
+	return func(ref common.ReferenceCallback) map[string]common.OpenAPIDefinition {
+		out := defs(ref)
+
+		name := typeName(v0alpha1.Folder{})
+		def, ok := out[name]
+		if !ok {
+			return out
+		}
+
+		if props := def.Schema.SchemaProps.Properties; props != nil {
+			if specProp, ok := props["spec"]; ok {
+				if specProp.Extensions == nil {
+					specProp.Extensions = map[string]interface{}{}
+				}
+				specProp.Extensions["x-kubernetes-patch-strategy"] = "merge"
+				props["spec"] = specProp
+			}
+		}
+
+		out[name] = def
+		return out
+	}
+}
+
+// typeName mirrors the fully-qualified type name openapi-gen uses as a
+// map key, e.g. "github.com/grafana/grafana/pkg/apis/folder/v0alpha1.Folder".
+func typeName(obj interface{}) string {
+	t := reflect.TypeOf(obj)
+	return t.PkgPath() + "." + t.Name()
+}
@@ -117,10 +117,18 @@ func (b *FolderAPIBuilder) GetAPIGroupInfo(
 			}),
 	}
 
+	fieldManager := FieldManager{}
+
 	storage := map[string]rest.Storage{}
 	storage[resourceInfo.StoragePath()] = legacyStore
-	storage[resourceInfo.StoragePath("parents")] = &subParentsREST{b.folderSvc}
-	storage[resourceInfo.StoragePath("children")] = &subChildrenREST{b.folderSvc}
+	storage[resourceInfo.StoragePath("parents")] = &ssaConflictChecker{
+		Storage:      &subParentsREST{b.folderSvc},
+		fieldManager: fieldManager,
+	}
+	storage[resourceInfo.StoragePath("children")] = &ssaConflictChecker{
+		Storage:      &subChildrenREST{b.folderSvc},
+		fieldManager: fieldManager,
+	}
 
 	// enable dual writes if a RESTOptionsGetter is provided
 	if dualWrite && optsGetter != nil {
@@ -136,7 +144,7 @@ func (b *FolderAPIBuilder) GetAPIGroupInfo(
 }
 
 func (b *FolderAPIBuilder) GetOpenAPIDefinitions() common.GetOpenAPIDefinitions {
-	return v0alpha1.GetOpenAPIDefinitions
+	return withSSAExtensions(v0alpha1.GetOpenAPIDefinitions)
 }
 
 func (b *FolderAPIBuilder) GetAPIRoutes() *builder.APIRoutes {
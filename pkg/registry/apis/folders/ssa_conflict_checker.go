@@ -0,0 +1,110 @@
+package folders
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/registry/rest"
+
+	"github.com/grafana/grafana/pkg/apis/folder/v0alpha1"
+	"github.com/grafana/grafana/pkg/services/apiserver/utils"
+)
+
+// ssaConflictChecker wraps subParentsREST/subChildrenREST so a reparenting
+// update goes through FieldManager.CheckReparentConflict before it's
+// allowed to proceed. Without this, the legacy folder service behind
+// those endpoints would silently let one manager's move clobber a field
+// another manager owns, instead of surfacing an SSA conflict.
+type ssaConflictChecker struct {
+	rest.Storage
+	fieldManager FieldManager
+}
+
+var _ rest.Updater = (*ssaConflictChecker)(nil)
+
+func (s *ssaConflictChecker) Update(
+	ctx context.Context,
+	name string,
+	objInfo rest.UpdatedObjectInfo,
+	createValidation rest.ValidateObjectFunc,
+	updateValidation rest.ValidateObjectUpdateFunc,
+	forceAllowCreate bool,
+	options *metav1.UpdateOptions,
+) (runtime.Object, bool, error) {
+	updater, ok := s.Storage.(rest.Updater)
+	if !ok {
+		return nil, false, fmt.Errorf("folders: %T does not support update", s.Storage)
+	}
+
+	manager := ""
+	if options != nil {
+		manager = options.FieldManager
+	}
+
+	old, err := s.get(ctx, name)
+	if err == nil && old != nil {
+		updated, err := objInfo.UpdatedObject(ctx, old)
+		if err != nil {
+			return nil, false, err
+		}
+		folderObj, ok := updated.(*v0alpha1.Folder)
+		if ok {
+			patchOpts := &metav1.PatchOptions{}
+			if options != nil && options.DryRun != nil {
+				patchOpts.DryRun = options.DryRun
+			}
+			if err := CheckReparentConflict(s.fieldManager, folderObj, manager, patchOpts); err != nil {
+				return nil, false, err
+			}
+		}
+	}
+
+	obj, created, err := updater.Update(ctx, name, objInfo, createValidation, updateValidation, forceAllowCreate, options)
+	if err != nil {
+		return obj, created, err
+	}
+
+	// Record manager as the new owner of parentUIDField now that the
+	// update has actually landed - doing this before updater.Update
+	// returned would claim ownership of a change that might still fail
+	// validation or a conflicting concurrent write.
+	if folderObj, ok := obj.(*v0alpha1.Folder); ok && manager != "" {
+		accessor, aerr := utils.MetaAccessor(folderObj)
+		if aerr != nil {
+			return obj, created, aerr
+		}
+		if err := s.fieldManager.ClaimFields(accessor, manager, []string{parentUIDField}); err != nil {
+			return obj, created, err
+		}
+
+		// ClaimFields only set the managed-fields annotation on folderObj
+		// in memory. Without persisting that through Storage, the next
+		// request's s.get would read the un-claimed annotation straight
+		// back out of legacy storage and CheckReparentConflict could
+		// never find a real owner. Issue a follow-up update carrying just
+		// that annotation change; validation is skipped since it only
+		// re-persists what the update above already accepted.
+		persisted, _, perr := updater.Update(
+			ctx, name, rest.DefaultUpdatedObjectInfo(folderObj),
+			func(ctx context.Context, obj runtime.Object) error { return nil },
+			func(ctx context.Context, obj, old runtime.Object) error { return nil },
+			false, options,
+		)
+		if perr != nil {
+			return obj, created, perr
+		}
+		obj = persisted
+	}
+
+	return obj, created, nil
+}
+
+func (s *ssaConflictChecker) get(ctx context.Context, name string) (runtime.Object, error) {
+	getter, ok := s.Storage.(rest.Getter)
+	if !ok {
+		return nil, nil
+	}
+	return getter.Get(ctx, name, &metav1.GetOptions{})
+}
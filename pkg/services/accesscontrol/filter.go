@@ -75,15 +75,16 @@ func Filter(user *user.SignedInUser, sqlID, prefix string, actions ...string) (S
 		return denyQuery, nil
 	}
 
-	query := strings.Builder{}
-	query.WriteRune(' ')
-	query.WriteString(sqlID)
-	query.WriteString(" IN ")
-	query.WriteString("(?")
-	query.WriteString(strings.Repeat(",?", len(ids)-1))
-	query.WriteRune(')')
-
-	return SQLFilter{query.String(), ids}, nil
+	// Bucket the id count to the next power of two and pad with nils so the
+	// generated "IN (?,?,...)" clause text can be cached and reused by
+	// cachedINClause regardless of which ids matched.
+	bucketSize := idBucketSize(len(ids))
+	clause := cachedINClause(sqlID, prefix, actions, wildcards, bucketSize)
+	for len(ids) < bucketSize {
+		ids = append(ids, nil)
+	}
+
+	return SQLFilter{clause, ids}, nil
 }
 
 func ParseScopes(prefix string, scopes []string) (ids map[interface{}]struct{}, hasWildcard bool) {
@@ -0,0 +1,158 @@
+// This file caches the generated SQL *text* for Filter's "IN (?,?,...)"
+// clause, not a prepared *sql.Stmt. Filter has no *sql.DB/*sql.Conn of its
+// own - it only ever returns a WHERE clause string and args for a caller
+// to embed in a larger xorm-built query - so there is nothing here that
+// could legally be turned into a driver-level prepared statement. Caching
+// the clause text removes the cost of rebuilding the "IN (?,?,...)" string
+// on every call; the database driver still parses the full query each
+// time, and the args are still computed fresh per call, since they depend
+// on the caller's ids.
+package accesscontrol
+
+import (
+	"hash/maphash"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// filterShapeSeed is process-local, matching the "hash maphash with a
+// process-local seed" approach: cache keys are only ever compared within
+// this process, so there is no need for a stable, cross-process hash.
+var filterShapeSeed = maphash.MakeSeed()
+
+// idBucketSize rounds n up to the next power of two, with a floor of 1.
+// Bucketing the id count means two calls to Filter that differ only in
+// which ids matched (but not how many, once rounded) share the same
+// cached clause shape.
+func idBucketSize(n int) int {
+	if n <= 1 {
+		return 1
+	}
+	size := 1
+	for size < n {
+		size <<= 1
+	}
+	return size
+}
+
+// filterShape is the cached, precompiled part of a Filter() call: the
+// WHERE clause text for a given (sqlID, prefix, actions, wildcards,
+// id-bucket) shape, with placeholders padded to bucketSize so the same
+// text can be reused regardless of exactly which ids matched.
+type filterShape struct {
+	clause     string
+	bucketSize int
+}
+
+var (
+	shapeCacheMu sync.RWMutex
+	shapeCache   = map[uint64]filterShape{}
+
+	filterCacheHits = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "grafana",
+		Subsystem: "accesscontrol",
+		Name:      "filter_shape_cache_hits_total",
+		Help:      "Number of accesscontrol.Filter calls that reused a cached WHERE clause shape.",
+	})
+	filterCacheMisses = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: "grafana",
+		Subsystem: "accesscontrol",
+		Name:      "filter_shape_cache_misses_total",
+		Help:      "Number of accesscontrol.Filter calls that had to build a new WHERE clause shape.",
+	})
+)
+
+func init() {
+	prometheus.MustRegister(filterCacheHits, filterCacheMisses)
+}
+
+// hashFilterShape computes the cache key for a Filter() call shape. It
+// must only depend on values that determine the generated clause text,
+// not on the concrete ids being filtered.
+func hashFilterShape(sqlID, prefix string, actions []string, wildcards, bucketSize int) uint64 {
+	sorted := append([]string(nil), actions...)
+	sortStrings(sorted)
+
+	var h maphash.Hash
+	h.SetSeed(filterShapeSeed)
+	_, _ = h.WriteString(sqlID)
+	_, _ = h.WriteString("\x00")
+	_, _ = h.WriteString(prefix)
+	_, _ = h.WriteString("\x00")
+	_, _ = h.WriteString(strings.Join(sorted, ","))
+	_, _ = h.WriteString("\x00")
+	_, _ = h.WriteString(strconv.Itoa(wildcards))
+	_, _ = h.WriteString("\x00")
+	_, _ = h.WriteString(strconv.Itoa(bucketSize))
+	return h.Sum64()
+}
+
+// buildINClause returns a fresh "sqlID IN (?,?,...)" clause with exactly
+// count placeholders.
+func buildINClause(sqlID string, count int) string {
+	query := strings.Builder{}
+	query.WriteRune(' ')
+	query.WriteString(sqlID)
+	query.WriteString(" IN ")
+	query.WriteString("(?")
+	query.WriteString(strings.Repeat(",?", count-1))
+	query.WriteRune(')')
+	return query.String()
+}
+
+// cachedINClause returns the clause text for sqlID/prefix/actions/wildcards
+// at the given bucketSize, building and caching it on first use. Callers
+// pad ids to bucketSize with nil before binding them as args.
+func cachedINClause(sqlID, prefix string, actions []string, wildcards, bucketSize int) string {
+	key := hashFilterShape(sqlID, prefix, actions, wildcards, bucketSize)
+
+	shapeCacheMu.RLock()
+	shape, ok := shapeCache[key]
+	shapeCacheMu.RUnlock()
+	if ok {
+		filterCacheHits.Inc()
+		return shape.clause
+	}
+
+	filterCacheMisses.Inc()
+	clause := buildINClause(sqlID, bucketSize)
+
+	shapeCacheMu.Lock()
+	shapeCache[key] = filterShape{clause: clause, bucketSize: bucketSize}
+	shapeCacheMu.Unlock()
+
+	return clause
+}
+
+// InvalidateFilterShapeCache drops every cached clause shape.
+func InvalidateFilterShapeCache() {
+	shapeCacheMu.Lock()
+	defer shapeCacheMu.Unlock()
+	shapeCache = map[uint64]filterShape{}
+}
+
+// OnPermissionsChanged is the hook the role/permission write path calls
+// after a role or permission assignment changes. The cached clause shapes
+// don't depend on which ids a user can see - those are never cached, only
+// the placeholder text - so today there is nothing for a permission change
+// to actually invalidate; this exists as the single call site that write
+// path should use if that ever stops being true (e.g. the accept list or
+// action set becomes dynamic per-install), so the cache doesn't silently
+// go stale later.
+func OnPermissionsChanged() {
+	InvalidateFilterShapeCache()
+}
+
+func sortStrings(s []string) {
+	// Small, bounded by the number of actions passed to Filter (a handful
+	// at most), so a simple insertion sort avoids pulling in sort.Strings
+	// for a single call site.
+	for i := 1; i < len(s); i++ {
+		for j := i; j > 0 && s[j-1] > s[j]; j-- {
+			s[j-1], s[j] = s[j], s[j-1]
+		}
+	}
+}
@@ -0,0 +1,122 @@
+package accesscontrol
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/grafana/grafana/pkg/services/user"
+)
+
+func userWithDashboardUIDs(uids ...string) *user.SignedInUser {
+	scopes := make([]string, len(uids))
+	for i, uid := range uids {
+		scopes[i] = "dashboard.uid:" + uid
+	}
+	return &user.SignedInUser{
+		OrgID: 1,
+		Permissions: map[int64]map[string][]string{
+			1: {"dashboards:read": scopes},
+		},
+	}
+}
+
+// TestFilter_BucketedClauseMatchesIDCount verifies the cached/bucketed IN
+// clause still carries exactly as many args as placeholders across bucket
+// boundaries (1, 2 and 5 matching ids), and that the original ids are
+// still present among the (possibly nil-padded) args, for every bucket
+// size Filter can produce.
+func TestFilter_BucketedClauseMatchesIDCount(t *testing.T) {
+	for _, n := range []int{1, 2, 5} {
+		uids := make([]string, n)
+		for i := range uids {
+			uids[i] = fmt.Sprintf("uid-%d", i+1)
+		}
+
+		signedInUser := userWithDashboardUIDs(uids...)
+		filter, err := Filter(signedInUser, "dashboard.uid", "dashboard.uid:", "dashboards:read")
+		if err != nil {
+			t.Fatalf("n=%d: Filter returned error: %v", n, err)
+		}
+
+		placeholders := countPlaceholders(filter.Where)
+		if placeholders != len(filter.Args) {
+			t.Fatalf("n=%d: clause has %d placeholders but %d args", n, placeholders, len(filter.Args))
+		}
+
+		bucket := idBucketSize(n)
+		if len(filter.Args) != bucket {
+			t.Fatalf("n=%d: expected %d args (bucket size), got %d", n, bucket, len(filter.Args))
+		}
+
+		seen := map[string]bool{}
+		for _, arg := range filter.Args {
+			if uid, ok := arg.(string); ok {
+				seen[uid] = true
+			}
+		}
+		for _, uid := range uids {
+			if !seen[uid] {
+				t.Fatalf("n=%d: expected uid %q among filter args, got %#v", n, uid, filter.Args)
+			}
+		}
+	}
+}
+
+func countPlaceholders(clause string) int {
+	count := 0
+	for _, r := range clause {
+		if r == '?' {
+			count++
+		}
+	}
+	return count
+}
+
+func TestFilter_ReusesCachedClauseAcrossCallsWithSameShape(t *testing.T) {
+	InvalidateFilterShapeCache()
+	before := userWithDashboardUIDs("uid-1", "uid-2")
+	after := userWithDashboardUIDs("uid-3", "uid-4")
+
+	filterBefore, err := Filter(before, "dashboard.uid", "dashboard.uid:", "dashboards:read")
+	if err != nil {
+		t.Fatalf("Filter returned error: %v", err)
+	}
+	filterAfter, err := Filter(after, "dashboard.uid", "dashboard.uid:", "dashboards:read")
+	if err != nil {
+		t.Fatalf("Filter returned error: %v", err)
+	}
+
+	if filterBefore.Where != filterAfter.Where {
+		t.Fatalf("expected two calls with the same shape to reuse the cached clause text, got %q vs %q", filterBefore.Where, filterAfter.Where)
+	}
+
+	shapeCacheMu.RLock()
+	size := len(shapeCache)
+	shapeCacheMu.RUnlock()
+	if size != 1 {
+		t.Fatalf("expected both calls to share one cached shape, got %d entries", size)
+	}
+}
+
+func TestOnPermissionsChanged_ClearsShapeCache(t *testing.T) {
+	signedInUser := userWithDashboardUIDs("uid-1")
+	if _, err := Filter(signedInUser, "dashboard.uid", "dashboard.uid:", "dashboards:read"); err != nil {
+		t.Fatalf("Filter returned error: %v", err)
+	}
+
+	shapeCacheMu.RLock()
+	sizeBefore := len(shapeCache)
+	shapeCacheMu.RUnlock()
+	if sizeBefore == 0 {
+		t.Fatal("expected Filter to populate the shape cache")
+	}
+
+	OnPermissionsChanged()
+
+	shapeCacheMu.RLock()
+	sizeAfter := len(shapeCache)
+	shapeCacheMu.RUnlock()
+	if sizeAfter != 0 {
+		t.Fatalf("expected OnPermissionsChanged to clear the shape cache, got %d entries", sizeAfter)
+	}
+}
@@ -0,0 +1,141 @@
+package searchV2
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+)
+
+// IndexerBackend identifies the concrete search engine behind an Indexer.
+type IndexerBackend string
+
+const (
+	// IndexerBackendBluge is the default in-process backend. It keeps the
+	// full dashboard index in memory and is rebuilt on every restart.
+	IndexerBackendBluge IndexerBackend = "bluge"
+	// IndexerBackendBleve stores the index on disk via blevesearch/bleve,
+	// allowing it to be shared across HA Grafana instances through a
+	// common volume.
+	IndexerBackendBleve IndexerBackend = "bleve"
+	// IndexerBackendElasticsearch delegates indexing and querying to an
+	// external Elasticsearch cluster.
+	IndexerBackendElasticsearch IndexerBackend = "elasticsearch"
+	// IndexerBackendOpenSearch delegates indexing and querying to an
+	// external OpenSearch cluster.
+	IndexerBackendOpenSearch IndexerBackend = "opensearch"
+)
+
+// IndexerConfig selects and configures the Indexer used by the
+// SearchService. Backend defaults to IndexerBackendBluge when empty.
+type IndexerConfig struct {
+	Backend IndexerBackend
+
+	// Addresses is the list of backend endpoints. Unused by the bluge
+	// backend, required by elasticsearch/opensearch.
+	Addresses []string
+	// Index is the backend-native index/collection name.
+	Index string
+}
+
+// Document is the backend-agnostic representation of a single indexed
+// entity (dashboard, folder or panel). Indexers translate it into
+// whatever native document shape they require.
+type Document struct {
+	Kind           string
+	UID            string
+	OrgID          int64
+	Location       string
+	Tags           []string
+	PanelType      string
+	DatasourceUID  string
+	DatasourceType string
+	HasPreview     string
+
+	// Fields carries the remaining backend-agnostic fields (title,
+	// description, schema version, ...) keyed by name so new fields don't
+	// require changes to every Indexer implementation.
+	Fields map[string]interface{}
+}
+
+// IndexerResult is what an Indexer returns for a DashboardQuery. It mirrors
+// the subset of backend.DataResponse that callers need in order to build
+// the final response frame.
+type IndexerResult struct {
+	Frame     *backend.DataResponse
+	TotalHits int64
+	// Explanations holds the per-hit scoring/explanation payload, keyed by
+	// UID, when the query requested DashboardQuery.Explain.
+	Explanations map[string]interface{}
+}
+
+// Indexer is implemented by each pluggable search backend. A SearchService
+// routes DoDashboardQuery through the configured Indexer so the index can be
+// swapped (in-memory/Bluge, Bleve, Elasticsearch, OpenSearch) without
+// touching the query API.
+//
+//go:generate mockery --name Indexer --structname MockIndexer --inpackage --filename indexer_mock.go
+type Indexer interface {
+	// Index adds or replaces docs in the backend.
+	Index(ctx context.Context, docs []Document) error
+	// Search runs query against the backend's native query DSL and
+	// returns results translated back into DashboardQuery's shape.
+	Search(ctx context.Context, orgID int64, query DashboardQuery) (*IndexerResult, error)
+	// Delete removes the document identified by uid from the backend.
+	Delete(ctx context.Context, uid string) error
+	// Reindex discards the backend's index and starts over with an empty
+	// one; previously indexed documents are not re-added.
+	Reindex(ctx context.Context) error
+}
+
+// resultRow is the per-hit data every backend collects from its native
+// search results before handing them to buildDashboardFrame. It's the
+// common shape the four Search implementations reduce their
+// backend-specific hit types down to.
+type resultRow struct {
+	UID      string
+	Kind     string
+	Title    string
+	Location string
+}
+
+// buildDashboardFrame assembles rows into the uid/kind/title/location
+// frame DoDashboardQuery callers expect back, wrapped in the
+// backend.DataResponse shape IndexerResult.Frame carries.
+func buildDashboardFrame(rows []resultRow) *backend.DataResponse {
+	uid := make([]string, len(rows))
+	kind := make([]string, len(rows))
+	title := make([]string, len(rows))
+	location := make([]string, len(rows))
+	for i, r := range rows {
+		uid[i] = r.UID
+		kind[i] = r.Kind
+		title[i] = r.Title
+		location[i] = r.Location
+	}
+
+	frame := data.NewFrame("",
+		data.NewField("uid", nil, uid),
+		data.NewField("kind", nil, kind),
+		data.NewField("title", nil, title),
+		data.NewField("location", nil, location),
+	)
+	return &backend.DataResponse{Frames: data.Frames{frame}}
+}
+
+// NewIndexer constructs the Indexer selected by cfg.Backend.
+func NewIndexer(cfg IndexerConfig) (Indexer, error) {
+	switch cfg.Backend {
+	case "", IndexerBackendBluge:
+		return newBlugeIndexer(cfg)
+	case IndexerBackendBleve:
+		return newBleveIndexer(cfg)
+	case IndexerBackendElasticsearch:
+		return newElasticsearchIndexer(cfg)
+	case IndexerBackendOpenSearch:
+		return newOpenSearchIndexer(cfg)
+	default:
+		return nil, fmt.Errorf("unknown search indexer backend: %q", cfg.Backend)
+	}
+}
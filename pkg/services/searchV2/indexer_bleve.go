@@ -0,0 +1,196 @@
+package searchV2
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/search/query"
+)
+
+// bleveIndexer persists the dashboard index to disk (or a shared volume),
+// which lets it be reused across HA Grafana instances instead of being
+// rebuilt in every process.
+type bleveIndexer struct {
+	mu    sync.RWMutex
+	path  string
+	index bleve.Index
+}
+
+func newBleveIndexer(cfg IndexerConfig) (Indexer, error) {
+	path := cfg.Index
+	if path == "" {
+		path = "dashboard-index.bleve"
+	}
+
+	idx, err := bleve.Open(path)
+	if err == bleve.ErrorIndexPathDoesNotExist {
+		idx, err = bleve.New(path, bleve.NewIndexMapping())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("opening bleve index %q: %w", path, err)
+	}
+
+	return &bleveIndexer{path: path, index: idx}, nil
+}
+
+// bleveDoc is what actually gets indexed, instead of the raw Document:
+// Document.Fields is an untyped nested map that bleve's reflect-based
+// default mapping can't query or retrieve predictably, so the handful of
+// extra fields DoDashboardQuery needs back (currently just Title) are
+// promoted to their own top-level, typed field here.
+type bleveDoc struct {
+	Kind          string
+	UID           string
+	OrgID         int64
+	Location      string
+	Tags          []string
+	PanelType     string
+	DatasourceUID string
+	Title         string
+}
+
+func toBleveDoc(doc Document) bleveDoc {
+	title, _ := doc.Fields["title"].(string)
+	return bleveDoc{
+		Kind:          doc.Kind,
+		UID:           doc.UID,
+		OrgID:         doc.OrgID,
+		Location:      doc.Location,
+		Tags:          doc.Tags,
+		PanelType:     doc.PanelType,
+		DatasourceUID: doc.DatasourceUID,
+		Title:         title,
+	}
+}
+
+func (b *bleveIndexer) Index(ctx context.Context, docs []Document) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	batch := b.index.NewBatch()
+	for _, doc := range docs {
+		if err := batch.Index(doc.UID, toBleveDoc(doc)); err != nil {
+			return err
+		}
+	}
+	return b.index.Batch(batch)
+}
+
+func (b *bleveIndexer) Search(ctx context.Context, orgID int64, q DashboardQuery) (*IndexerResult, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	req := dashboardQueryToBleve(orgID, q)
+	req.Fields = []string{"Kind", "Title", "Location"}
+	res, err := b.index.SearchInContext(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &IndexerResult{TotalHits: int64(res.Total)}
+	if q.Explain {
+		result.Explanations = make(map[string]interface{}, len(res.Hits))
+	}
+
+	rows := make([]resultRow, 0, len(res.Hits))
+	for _, hit := range res.Hits {
+		row := resultRow{UID: hit.ID}
+		if v, ok := hit.Fields["Kind"].(string); ok {
+			row.Kind = v
+		}
+		if v, ok := hit.Fields["Title"].(string); ok {
+			row.Title = v
+		}
+		if v, ok := hit.Fields["Location"].(string); ok {
+			row.Location = v
+		}
+		rows = append(rows, row)
+
+		if q.Explain && hit.Expl != nil {
+			result.Explanations[hit.ID] = hit.Expl.String()
+		}
+	}
+	result.Frame = buildDashboardFrame(rows)
+
+	return result, nil
+}
+
+func (b *bleveIndexer) Delete(ctx context.Context, uid string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.index.Delete(uid)
+}
+
+func (b *bleveIndexer) Reindex(ctx context.Context) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err := b.index.Close(); err != nil {
+		return err
+	}
+	// bleve.New refuses an existing path, so drop the old index first.
+	if err := os.RemoveAll(b.path); err != nil {
+		return err
+	}
+	idx, err := bleve.New(b.path, bleve.NewIndexMapping())
+	if err != nil {
+		return err
+	}
+	b.index = idx
+	return nil
+}
+
+// dashboardQueryToBleve maps DashboardQuery's facets, tags, kind, ds_uid,
+// panel_type, location, from/limit and sort onto bleve's native query DSL,
+// scoped to orgID so a query never matches another org's documents. Field
+// names match bleveDoc's Go field names, since bleve's default reflect
+// mapping indexes struct fields under their literal field name.
+func dashboardQueryToBleve(orgID int64, q DashboardQuery) *bleve.SearchRequest {
+	var qr query.Query
+	if q.Query != "" {
+		qr = bleve.NewMatchQuery(q.Query)
+	} else {
+		qr = bleve.NewMatchAllQuery()
+	}
+
+	orgVal := float64(orgID)
+	inclusive := true
+	conjuncts := []query.Query{qr, bleve.NewNumericRangeInclusiveQuery(&orgVal, &orgVal, &inclusive, &inclusive).SetField("OrgID")}
+	for _, tag := range q.Tags {
+		conjuncts = append(conjuncts, bleve.NewTermQuery(tag).SetField("Tags"))
+	}
+	for _, kind := range q.Kind {
+		conjuncts = append(conjuncts, bleve.NewTermQuery(kind).SetField("Kind"))
+	}
+	if q.Datasource != "" {
+		conjuncts = append(conjuncts, bleve.NewTermQuery(q.Datasource).SetField("DatasourceUID"))
+	}
+	if q.PanelType != "" {
+		conjuncts = append(conjuncts, bleve.NewTermQuery(q.PanelType).SetField("PanelType"))
+	}
+	if q.Location != "" {
+		conjuncts = append(conjuncts, bleve.NewTermQuery(q.Location).SetField("Location"))
+	}
+
+	limit := q.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+
+	req := bleve.NewSearchRequestOptions(bleve.NewConjunctionQuery(conjuncts...), limit, q.From, false)
+	req.Explain = q.Explain
+	if q.Sort != "" {
+		req.SortBy([]string{q.Sort})
+	}
+	for _, facet := range q.Facet {
+		size := facet.Limit
+		if size <= 0 {
+			size = 10
+		}
+		req.AddFacet(facet.Field, bleve.NewFacetRequest(facet.Field, size))
+	}
+	return req
+}
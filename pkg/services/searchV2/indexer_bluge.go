@@ -0,0 +1,195 @@
+package searchV2
+
+import (
+	"context"
+	"sync"
+
+	"github.com/blugelabs/bluge"
+)
+
+// blugeIndexer is the default in-process Indexer backed by an in-memory
+// bluge.Writer/Reader pair. It has no external dependencies, which is why
+// it remains the default backend, but it cannot be shared across HA
+// Grafana instances.
+type blugeIndexer struct {
+	mu     sync.RWMutex
+	writer *bluge.Writer
+}
+
+func newBlugeIndexer(cfg IndexerConfig) (Indexer, error) {
+	config := bluge.InMemoryOnlyConfig()
+	writer, err := bluge.OpenWriter(config)
+	if err != nil {
+		return nil, err
+	}
+	return &blugeIndexer{writer: writer}, nil
+}
+
+func (b *blugeIndexer) Index(ctx context.Context, docs []Document) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	batch := bluge.NewBatch()
+	for _, doc := range docs {
+		batch.Update(bluge.Identifier(doc.UID), documentToBluge(doc))
+	}
+	return b.writer.Batch(batch)
+}
+
+func (b *blugeIndexer) Search(ctx context.Context, orgID int64, query DashboardQuery) (*IndexerResult, error) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	reader, err := b.writer.Reader()
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = reader.Close() }()
+
+	req, err := dashboardQueryToBluge(orgID, query)
+	if err != nil {
+		return nil, err
+	}
+
+	matches, err := reader.Search(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &IndexerResult{}
+	if query.Explain {
+		result.Explanations = make(map[string]interface{})
+	}
+
+	var rows []resultRow
+	match, err := matches.Next()
+	for err == nil && match != nil {
+		result.TotalHits++
+
+		row := resultRow{UID: string(match.ID)}
+		_ = match.VisitStoredFields(func(field string, value []byte) bool {
+			switch field {
+			case "kind":
+				row.Kind = string(value)
+			case "title":
+				row.Title = string(value)
+			case "location":
+				row.Location = string(value)
+			}
+			return true
+		})
+		rows = append(rows, row)
+
+		if query.Explain && match.Explanation != nil {
+			result.Explanations[row.UID] = match.Explanation.String()
+		}
+		match, err = matches.Next()
+	}
+	result.Frame = buildDashboardFrame(rows)
+
+	return result, nil
+}
+
+func (b *blugeIndexer) Delete(ctx context.Context, uid string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.writer.Delete(bluge.Identifier(uid))
+}
+
+func (b *blugeIndexer) Reindex(ctx context.Context) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	config := bluge.InMemoryOnlyConfig()
+	writer, err := bluge.OpenWriter(config)
+	if err != nil {
+		return err
+	}
+	_ = b.writer.Close()
+	b.writer = writer
+	return nil
+}
+
+// documentToBluge maps the backend-agnostic Document onto a bluge.Document.
+func documentToBluge(doc Document) *bluge.Document {
+	bdoc := bluge.NewDocument(doc.UID).
+		AddField(bluge.NewKeywordField("kind", doc.Kind).StoreValue()).
+		AddField(bluge.NewNumericField("org_id", float64(doc.OrgID)))
+
+	if doc.Location != "" {
+		bdoc.AddField(bluge.NewKeywordField("location", doc.Location).StoreValue())
+	}
+	for _, tag := range doc.Tags {
+		bdoc.AddField(bluge.NewKeywordField("tag", tag).StoreValue())
+	}
+	if doc.PanelType != "" {
+		bdoc.AddField(bluge.NewKeywordField("panel_type", doc.PanelType).StoreValue())
+	}
+	if doc.DatasourceUID != "" {
+		bdoc.AddField(bluge.NewKeywordField("ds_uid", doc.DatasourceUID).StoreValue())
+	}
+	if doc.DatasourceType != "" {
+		bdoc.AddField(bluge.NewKeywordField("ds_type", doc.DatasourceType).StoreValue())
+	}
+	if doc.HasPreview != "" {
+		bdoc.AddField(bluge.NewKeywordField("has_preview", doc.HasPreview).StoreValue())
+	}
+	if title, ok := doc.Fields["title"].(string); ok && title != "" {
+		bdoc.AddField(bluge.NewKeywordField("title", title).StoreValue())
+	}
+	return bdoc
+}
+
+// dashboardQueryToBluge maps DashboardQuery's facets, tags, kind, ds_uid,
+// panel_type, location, from/limit and sort onto bluge's native request
+// builders, scoped to orgID so a query never matches another org's
+// documents.
+func dashboardQueryToBluge(orgID int64, query DashboardQuery) (bluge.SearchRequest, error) {
+	var q bluge.Query
+	if query.Query != "" {
+		q = bluge.NewMatchQuery(query.Query)
+	} else {
+		q = bluge.NewMatchAllQuery()
+	}
+
+	conjunction := bluge.NewBooleanQuery().AddMust(q)
+	conjunction.AddMust(bluge.NewNumericRangeInclusiveQuery(float64(orgID), float64(orgID), true, true).SetField("org_id"))
+	for _, tag := range query.Tags {
+		conjunction.AddMust(bluge.NewTermQuery(tag).SetField("tag"))
+	}
+	for _, kind := range query.Kind {
+		conjunction.AddMust(bluge.NewTermQuery(kind).SetField("kind"))
+	}
+	if query.Datasource != "" {
+		conjunction.AddMust(bluge.NewTermQuery(query.Datasource).SetField("ds_uid"))
+	}
+	if query.PanelType != "" {
+		conjunction.AddMust(bluge.NewTermQuery(query.PanelType).SetField("panel_type"))
+	}
+	if query.Location != "" {
+		conjunction.AddMust(bluge.NewTermQuery(query.Location).SetField("location"))
+	}
+	if query.HasPreview != "" {
+		conjunction.AddMust(bluge.NewTermQuery(query.HasPreview).SetField("has_preview"))
+	}
+
+	limit := query.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+
+	req := bluge.NewTopNSearch(limit, conjunction).
+		SetFrom(query.From).
+		WithStandardAggregations()
+	if query.Explain {
+		req = req.ExplainScores()
+	}
+	if query.Sort != "" {
+		req = req.SortBy([]string{query.Sort})
+	}
+	for _, facet := range query.Facet {
+		req = req.AddAggregation(facet.Field, bluge.NewTermsAggregation(bluge.NewFieldSource(facet.Field), facet.Limit))
+	}
+
+	return req, nil
+}
@@ -0,0 +1,100 @@
+package searchV2
+
+import (
+	"context"
+	"testing"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+)
+
+func TestNewIndexer_DefaultsToBluge(t *testing.T) {
+	indexer, err := NewIndexer(IndexerConfig{})
+	if err != nil {
+		t.Fatalf("NewIndexer returned error: %v", err)
+	}
+	if _, ok := indexer.(*blugeIndexer); !ok {
+		t.Fatalf("expected *blugeIndexer, got %T", indexer)
+	}
+}
+
+func TestBlugeIndexer_IndexAndSearch(t *testing.T) {
+	indexer, err := NewIndexer(IndexerConfig{Backend: IndexerBackendBluge})
+	if err != nil {
+		t.Fatalf("NewIndexer returned error: %v", err)
+	}
+
+	ctx := context.Background()
+	err = indexer.Index(ctx, []Document{
+		{Kind: "dashboard", UID: "abc", OrgID: 1, Tags: []string{"prod"}, Fields: map[string]interface{}{"title": "Prod overview"}},
+		{Kind: "folder", UID: "def", OrgID: 1},
+		{Kind: "dashboard", UID: "xyz", OrgID: 2, Fields: map[string]interface{}{"title": "Other org"}},
+	})
+	if err != nil {
+		t.Fatalf("Index returned error: %v", err)
+	}
+
+	result, err := indexer.Search(ctx, 1, DashboardQuery{Kind: []string{"dashboard"}})
+	if err != nil {
+		t.Fatalf("Search returned error: %v", err)
+	}
+	if result.TotalHits != 1 {
+		t.Fatalf("expected 1 hit, got %d", result.TotalHits)
+	}
+	if result.Frame == nil || len(result.Frame.Frames) != 1 {
+		t.Fatalf("expected a single frame in the result, got %#v", result.Frame)
+	}
+	assertFrameUIDsAndTitles(t, result.Frame.Frames[0], map[string]string{"abc": "Prod overview"})
+
+	otherOrg, err := indexer.Search(ctx, 2, DashboardQuery{Kind: []string{"dashboard"}})
+	if err != nil {
+		t.Fatalf("Search returned error: %v", err)
+	}
+	assertFrameUIDsAndTitles(t, otherOrg.Frame.Frames[0], map[string]string{"xyz": "Other org"})
+
+	if err := indexer.Delete(ctx, "abc"); err != nil {
+		t.Fatalf("Delete returned error: %v", err)
+	}
+	if err := indexer.Reindex(ctx); err != nil {
+		t.Fatalf("Reindex returned error: %v", err)
+	}
+}
+
+// assertFrameUIDsAndTitles checks that frame's uid/title fields carry
+// exactly the expected uid -> title pairs, proving Search actually
+// populates the DataResponse instead of always falling through to an
+// empty one.
+func assertFrameUIDsAndTitles(t *testing.T, frame *data.Frame, want map[string]string) {
+	t.Helper()
+
+	var uidField, titleField *data.Field
+	for _, field := range frame.Fields {
+		switch field.Name {
+		case "uid":
+			uidField = field
+		case "title":
+			titleField = field
+		}
+	}
+	if uidField == nil {
+		t.Fatal("frame has no uid field")
+	}
+	if titleField == nil {
+		t.Fatal("frame has no title field")
+	}
+
+	got := map[string]string{}
+	for i := 0; i < uidField.Len(); i++ {
+		uid, _ := uidField.At(i).(string)
+		title, _ := titleField.At(i).(string)
+		got[uid] = title
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("expected %d rows, got %d (%v)", len(want), len(got), got)
+	}
+	for uid, title := range want {
+		if got[uid] != title {
+			t.Fatalf("uid %q: expected title %q, got %q", uid, title, got[uid])
+		}
+	}
+}
@@ -0,0 +1,231 @@
+package searchV2
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/elastic/go-elasticsearch/v8"
+	"github.com/elastic/go-elasticsearch/v8/esapi"
+)
+
+// elasticsearchIndexer delegates indexing and querying to an external
+// Elasticsearch cluster so the dashboard index can be shared and scaled
+// independently of any single Grafana process.
+type elasticsearchIndexer struct {
+	client *elasticsearch.Client
+	index  string
+}
+
+func newElasticsearchIndexer(cfg IndexerConfig) (Indexer, error) {
+	index := cfg.Index
+	if index == "" {
+		index = "grafana-dashboards"
+	}
+
+	client, err := elasticsearch.NewClient(elasticsearch.Config{Addresses: cfg.Addresses})
+	if err != nil {
+		return nil, fmt.Errorf("creating elasticsearch client: %w", err)
+	}
+
+	return &elasticsearchIndexer{client: client, index: index}, nil
+}
+
+func (e *elasticsearchIndexer) Index(ctx context.Context, docs []Document) error {
+	var buf strings.Builder
+	for _, doc := range docs {
+		meta, _ := json.Marshal(map[string]interface{}{
+			"index": map[string]interface{}{"_index": e.index, "_id": doc.UID},
+		})
+		body, err := json.Marshal(documentToESSource(doc))
+		if err != nil {
+			return err
+		}
+		buf.Write(meta)
+		buf.WriteByte('\n')
+		buf.Write(body)
+		buf.WriteByte('\n')
+	}
+
+	res, err := e.client.Bulk(strings.NewReader(buf.String()), e.client.Bulk.WithContext(ctx), e.client.Bulk.WithIndex(e.index))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = res.Body.Close() }()
+	if res.IsError() {
+		return fmt.Errorf("elasticsearch bulk index failed: %s", res.String())
+	}
+	return nil
+}
+
+func (e *elasticsearchIndexer) Search(ctx context.Context, orgID int64, query DashboardQuery) (*IndexerResult, error) {
+	body, err := json.Marshal(dashboardQueryToES(orgID, query))
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := e.client.Search(
+		e.client.Search.WithContext(ctx),
+		e.client.Search.WithIndex(e.index),
+		e.client.Search.WithBody(strings.NewReader(string(body))),
+		e.client.Search.WithExplain(query.Explain),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = res.Body.Close() }()
+	if res.IsError() {
+		return nil, fmt.Errorf("elasticsearch search failed: %s", res.String())
+	}
+
+	var parsed esSearchResponse
+	if err := json.NewDecoder(res.Body).Decode(&parsed); err != nil {
+		return nil, err
+	}
+
+	result := &IndexerResult{TotalHits: parsed.Hits.Total.Value}
+	if query.Explain {
+		result.Explanations = make(map[string]interface{}, len(parsed.Hits.Hits))
+		for _, hit := range parsed.Hits.Hits {
+			result.Explanations[hit.ID] = hit.Explanation
+		}
+	}
+	result.Frame = buildDashboardFrame(rowsFromESHits(parsed.Hits.Hits))
+	return result, nil
+}
+
+func (e *elasticsearchIndexer) Delete(ctx context.Context, uid string) error {
+	res, err := e.client.Delete(e.index, uid, e.client.Delete.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = res.Body.Close() }()
+	if res.IsError() && res.StatusCode != 404 {
+		return fmt.Errorf("elasticsearch delete failed: %s", res.String())
+	}
+	return nil
+}
+
+func (e *elasticsearchIndexer) Reindex(ctx context.Context) error {
+	res, err := esapi.IndicesDeleteRequest{Index: []string{e.index}}.Do(ctx, e.client)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = res.Body.Close() }()
+	// Missing index is fine; it will be created again on the next Index call.
+	if res.IsError() && res.StatusCode != 404 {
+		return fmt.Errorf("elasticsearch index delete failed: %s", res.String())
+	}
+	return nil
+}
+
+func documentToESSource(doc Document) map[string]interface{} {
+	source := map[string]interface{}{
+		"kind":      doc.Kind,
+		"org_id":    doc.OrgID,
+		"location":  doc.Location,
+		"tags":      doc.Tags,
+		"panelType": doc.PanelType,
+		"dsUid":     doc.DatasourceUID,
+		"dsType":    doc.DatasourceType,
+	}
+	for k, v := range doc.Fields {
+		source[k] = v
+	}
+	return source
+}
+
+// dashboardQueryToES maps DashboardQuery's facets, tags, kind, ds_uid,
+// panel_type, location, from/limit and sort onto Elasticsearch's Query
+// DSL, scoped to orgID so a query never matches another org's documents.
+func dashboardQueryToES(orgID int64, q DashboardQuery) map[string]interface{} {
+	must := []map[string]interface{}{
+		{"term": map[string]interface{}{"org_id": orgID}},
+	}
+	if q.Query != "" {
+		must = append(must, map[string]interface{}{"query_string": map[string]interface{}{"query": q.Query}})
+	} else {
+		must = append(must, map[string]interface{}{"match_all": map[string]interface{}{}})
+	}
+	for _, tag := range q.Tags {
+		must = append(must, map[string]interface{}{"term": map[string]interface{}{"tags": tag}})
+	}
+	for _, kind := range q.Kind {
+		must = append(must, map[string]interface{}{"term": map[string]interface{}{"kind": kind}})
+	}
+	if q.Datasource != "" {
+		must = append(must, map[string]interface{}{"term": map[string]interface{}{"dsUid": q.Datasource}})
+	}
+	if q.PanelType != "" {
+		must = append(must, map[string]interface{}{"term": map[string]interface{}{"panelType": q.PanelType}})
+	}
+	if q.Location != "" {
+		must = append(must, map[string]interface{}{"term": map[string]interface{}{"location": q.Location}})
+	}
+
+	limit := q.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+
+	body := map[string]interface{}{
+		"from":  q.From,
+		"size":  limit,
+		"query": map[string]interface{}{"bool": map[string]interface{}{"must": must}},
+	}
+	if q.Sort != "" {
+		body["sort"] = []string{q.Sort}
+	}
+	if len(q.Facet) > 0 {
+		aggs := map[string]interface{}{}
+		for _, facet := range q.Facet {
+			size := facet.Limit
+			if size <= 0 {
+				size = 10
+			}
+			aggs[facet.Field] = map[string]interface{}{"terms": map[string]interface{}{"field": facet.Field, "size": size}}
+		}
+		body["aggs"] = aggs
+	}
+	return body
+}
+
+// esHit is a single hit as returned by Elasticsearch/OpenSearch, which
+// share the same search response shape. Source carries the indexed
+// document (see documentToESSource) so rowsFromESHits can recover
+// kind/title/location without a second round trip.
+type esHit struct {
+	ID          string                 `json:"_id"`
+	Source      map[string]interface{} `json:"_source"`
+	Explanation interface{}            `json:"_explanation,omitempty"`
+}
+
+type esSearchResponse struct {
+	Hits struct {
+		Total struct {
+			Value int64 `json:"value"`
+		} `json:"total"`
+		Hits []esHit `json:"hits"`
+	} `json:"hits"`
+}
+
+// rowsFromESHits extracts the uid/kind/title/location columns
+// buildDashboardFrame needs from a page of Elasticsearch/OpenSearch hits.
+func rowsFromESHits(hits []esHit) []resultRow {
+	rows := make([]resultRow, 0, len(hits))
+	for _, hit := range hits {
+		row := resultRow{UID: hit.ID}
+		if kind, ok := hit.Source["kind"].(string); ok {
+			row.Kind = kind
+		}
+		if title, ok := hit.Source["title"].(string); ok {
+			row.Title = title
+		}
+		if location, ok := hit.Source["location"].(string); ok {
+			row.Location = location
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
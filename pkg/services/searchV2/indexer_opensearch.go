@@ -0,0 +1,119 @@
+package searchV2
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/opensearch-project/opensearch-go/v2"
+	"github.com/opensearch-project/opensearch-go/v2/opensearchapi"
+)
+
+// openSearchIndexer delegates indexing and querying to an external
+// OpenSearch cluster. It shares its query DSL mapping with the
+// Elasticsearch backend since both speak the same Query DSL dialect.
+type openSearchIndexer struct {
+	client *opensearch.Client
+	index  string
+}
+
+func newOpenSearchIndexer(cfg IndexerConfig) (Indexer, error) {
+	index := cfg.Index
+	if index == "" {
+		index = "grafana-dashboards"
+	}
+
+	client, err := opensearch.NewClient(opensearch.Config{Addresses: cfg.Addresses})
+	if err != nil {
+		return nil, fmt.Errorf("creating opensearch client: %w", err)
+	}
+
+	return &openSearchIndexer{client: client, index: index}, nil
+}
+
+func (o *openSearchIndexer) Index(ctx context.Context, docs []Document) error {
+	for _, doc := range docs {
+		body, err := json.Marshal(documentToESSource(doc))
+		if err != nil {
+			return err
+		}
+		req := opensearchapi.IndexRequest{
+			Index:      o.index,
+			DocumentID: doc.UID,
+			Body:       bytes.NewReader(body),
+		}
+		res, err := req.Do(ctx, o.client)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = res.Body.Close() }()
+		if res.IsError() {
+			return fmt.Errorf("opensearch index failed for %q: %s", doc.UID, res.String())
+		}
+	}
+	return nil
+}
+
+func (o *openSearchIndexer) Search(ctx context.Context, orgID int64, query DashboardQuery) (*IndexerResult, error) {
+	body, err := json.Marshal(dashboardQueryToES(orgID, query))
+	if err != nil {
+		return nil, err
+	}
+
+	req := opensearchapi.SearchRequest{
+		Index:   []string{o.index},
+		Body:    bytes.NewReader(body),
+		Explain: &query.Explain,
+	}
+	res, err := req.Do(ctx, o.client)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = res.Body.Close() }()
+	if res.IsError() {
+		return nil, fmt.Errorf("opensearch search failed: %s", res.String())
+	}
+
+	var parsed esSearchResponse
+	if err := json.NewDecoder(res.Body).Decode(&parsed); err != nil {
+		return nil, err
+	}
+
+	result := &IndexerResult{TotalHits: parsed.Hits.Total.Value}
+	if query.Explain {
+		result.Explanations = make(map[string]interface{}, len(parsed.Hits.Hits))
+		for _, hit := range parsed.Hits.Hits {
+			result.Explanations[hit.ID] = hit.Explanation
+		}
+	}
+	result.Frame = buildDashboardFrame(rowsFromESHits(parsed.Hits.Hits))
+	return result, nil
+}
+
+func (o *openSearchIndexer) Delete(ctx context.Context, uid string) error {
+	req := opensearchapi.DeleteRequest{Index: o.index, DocumentID: uid}
+	res, err := req.Do(ctx, o.client)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = res.Body.Close() }()
+	if res.IsError() && res.StatusCode != 404 {
+		return fmt.Errorf("opensearch delete failed: %s", res.String())
+	}
+	return nil
+}
+
+func (o *openSearchIndexer) Reindex(ctx context.Context) error {
+	req := opensearchapi.IndicesDeleteRequest{Index: []string{o.index}}
+	res, err := req.Do(ctx, o.client)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = res.Body.Close() }()
+	// A missing index is fine; it is created again on the next Index call.
+	if res.IsError() && res.StatusCode != 404 {
+		return fmt.Errorf("opensearch index delete failed: %s", res.String())
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package searchV2
+
+import (
+	"context"
+	"sync"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+)
+
+// StandardSearchService is the default SearchService implementation. It
+// delegates all indexing and querying to an Indexer: DoDashboardQuery maps
+// DashboardQuery onto the Indexer's native query DSL and maps IndexerResult
+// back (with per-hit explanations, keyed by uid, in the first frame's
+// Meta.Custom when Explain is set), so swapping
+// cfg.Backend (bluge/bleve/elasticsearch/opensearch) changes nothing else
+// about how callers use the service.
+type StandardSearchService struct {
+	indexer Indexer
+
+	mu        sync.RWMutex
+	extenders []DashboardIndexExtender
+	disabled  bool
+}
+
+// NewStandardSearchService builds a StandardSearchService around the
+// Indexer selected by cfg.
+func NewStandardSearchService(cfg IndexerConfig) (*StandardSearchService, error) {
+	indexer, err := NewIndexer(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &StandardSearchService{indexer: indexer}, nil
+}
+
+func (s *StandardSearchService) IsDisabled() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.disabled
+}
+
+func (s *StandardSearchService) Run(ctx context.Context) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func (s *StandardSearchService) DoDashboardQuery(ctx context.Context, _ *backend.User, orgId int64, query DashboardQuery) *backend.DataResponse {
+	result, err := s.indexer.Search(ctx, orgId, query)
+	if err != nil {
+		return &backend.DataResponse{Error: err}
+	}
+	if result.Frame != nil {
+		if query.Explain && len(result.Explanations) > 0 && len(result.Frame.Frames) > 0 {
+			frame := result.Frame.Frames[0]
+			if frame.Meta == nil {
+				frame.Meta = &data.FrameMeta{}
+			}
+			frame.Meta.Custom = result.Explanations
+		}
+		return result.Frame
+	}
+	return &backend.DataResponse{}
+}
+
+func (s *StandardSearchService) RegisterDashboardIndexExtender(ext DashboardIndexExtender) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.extenders = append(s.extenders, ext)
+}
+
+func (s *StandardSearchService) TriggerReIndex() {
+	_ = s.indexer.Reindex(context.Background())
+}
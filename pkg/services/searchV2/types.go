@@ -39,6 +39,10 @@ type DashboardQuery struct {
 	From               int          `json:"from,omitempty"`       // for paging
 }
 
+// SearchService routes DoDashboardQuery through a pluggable Indexer
+// (see indexer.go), so the dashboard index backend can be swapped via
+// config without changing this API.
+//
 //go:generate mockery --name SearchService --structname MockSearchService --inpackage --filename search_service_mock.go
 type SearchService interface {
 	registry.CanBeDisabled
@@ -0,0 +1,73 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+
+	"github.com/grafana/grafana/pkg/services/searchV2"
+)
+
+// pagedSearchService fakes searchV2.SearchService with a fixed set of
+// dashboard UIDs, split across pages according to the caller's
+// Limit/From, so tests can tell a single-page fetch apart from one that
+// stops after the first page.
+type pagedSearchService struct {
+	uids []string
+}
+
+func (p *pagedSearchService) IsDisabled() bool { return false }
+
+func (p *pagedSearchService) Run(ctx context.Context) error { return nil }
+
+func (p *pagedSearchService) RegisterDashboardIndexExtender(ext searchV2.DashboardIndexExtender) {}
+
+func (p *pagedSearchService) TriggerReIndex() {}
+
+func (p *pagedSearchService) DoDashboardQuery(ctx context.Context, _ *backend.User, _ int64, query searchV2.DashboardQuery) *backend.DataResponse {
+	limit := query.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+
+	from := query.From
+	if from > len(p.uids) {
+		from = len(p.uids)
+	}
+	to := from + limit
+	if to > len(p.uids) {
+		to = len(p.uids)
+	}
+	page := p.uids[from:to]
+
+	field := data.NewField("uid", nil, page)
+	frame := data.NewFrame("", field)
+	return &backend.DataResponse{Frames: data.Frames{frame}}
+}
+
+func TestAllUIDsForKind_ResolvesEveryPage(t *testing.T) {
+	const total = uidPageSize + 5
+
+	uids := make([]string, total)
+	for i := range uids {
+		uids[i] = fmt.Sprintf("uid-%d", i+1)
+	}
+
+	a := NewAnalyzer(&pagedSearchService{uids: uids})
+	got, err := a.allUIDsForKind(context.Background(), &backend.User{Login: "test"}, 1, "dashboard")
+	if err != nil {
+		t.Fatalf("allUIDsForKind returned error: %v", err)
+	}
+
+	if len(got) != total {
+		t.Fatalf("expected %d uids across all pages, got %d", total, len(got))
+	}
+	for i, uid := range uids {
+		if got[i] != uid {
+			t.Fatalf("uid %d: expected %q, got %q", i, uid, got[i])
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package analyzer
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	"github.com/grafana/grafana/pkg/web"
+)
+
+// TokenSubject is what a token or API key resolves to: the permissions it
+// grants, plus a human-readable name used for downstream index lookups,
+// so those lookups never have to carry the raw credential.
+type TokenSubject struct {
+	Login       string
+	Permissions map[string][]string
+}
+
+// TokenPermissionsLookup resolves a service-account token or API key to
+// its TokenSubject, without requiring the caller to authenticate as that
+// token. Implemented by the service-account/API key services.
+type TokenPermissionsLookup interface {
+	PermissionsForToken(ctx context.Context, orgID int64, token string) (*TokenSubject, error)
+}
+
+// API exposes Analyzer over HTTP as an admin-only endpoint, for operators
+// who'd rather audit a token from the UI or a script than the CLI.
+type API struct {
+	analyzer *Analyzer
+	lookup   TokenPermissionsLookup
+}
+
+// NewAPI builds an API backed by analyzer and lookup.
+func NewAPI(analyzer *Analyzer, lookup TokenPermissionsLookup) *API {
+	return &API{analyzer: analyzer, lookup: lookup}
+}
+
+// RegisterRoutes wires the analyzer under the admin API group. It's a
+// POST, not a GET like apikey.Service's other routes: the request body
+// carries the live credential being analyzed, and a GET with the token in
+// the URL path would land it in access logs, proxy logs and referrer
+// history.
+func (a *API) RegisterRoutes(group *web.RouteGroup) {
+	group.Post("/service-accounts/analyze", a.handleAnalyze)
+}
+
+type analyzeRequest struct {
+	Token string `json:"token"`
+}
+
+func (a *API) handleAnalyze(c *contextmodel.ReqContext) {
+	var req analyzeRequest
+	if err := json.NewDecoder(c.Req.Body).Decode(&req); err != nil {
+		c.JsonApiErr(http.StatusBadRequest, "invalid request body", err)
+		return
+	}
+	if req.Token == "" {
+		c.JsonApiErr(http.StatusBadRequest, "missing token", nil)
+		return
+	}
+
+	subject, err := a.lookup.PermissionsForToken(c.Req.Context(), c.OrgID, req.Token)
+	if err != nil {
+		c.JsonApiErr(http.StatusNotFound, "token not found", err)
+		return
+	}
+
+	report, err := a.analyzer.Analyze(c.Req.Context(), c.OrgID, subject)
+	if err != nil {
+		c.JsonApiErr(http.StatusInternalServerError, "failed to analyze token", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
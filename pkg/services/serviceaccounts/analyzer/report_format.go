@@ -0,0 +1,27 @@
+package analyzer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+)
+
+// WriteJSON writes report as indented JSON, for callers integrating with
+// other tooling.
+func WriteJSON(w io.Writer, report *Report) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(report)
+}
+
+// WriteTable writes report as an aligned, human-readable table, for
+// `grafana-cli service-accounts analyze`.
+func WriteTable(w io.Writer, report *Report) error {
+	tw := tabwriter.NewWriter(w, 0, 2, 2, ' ', 0)
+	fmt.Fprintln(tw, "KIND\tUID\tALLOWED ACTIONS")
+	for _, e := range report.Entities {
+		fmt.Fprintf(tw, "%s\t%s\t%v\n", e.Kind, e.UID, e.AllowedActions)
+	}
+	return tw.Flush()
+}
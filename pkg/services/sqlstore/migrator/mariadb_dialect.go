@@ -0,0 +1,53 @@
+package migrator
+
+import "xorm.io/xorm"
+
+// mariadbDialect threads MariaDB through the migration engine as its own
+// dialect instead of aliasing it to "mysql". MariaDB has diverged from
+// MySQL in ways that matter to migrations on newer releases (10.6/10.11/11):
+// the default collation (utf8mb4_general_ci vs. utf8mb4_unicode_ci), its
+// own JSON function set, sequence/RETURNING support, and sql_mode
+// defaults. It embeds mysqlDialect and overrides only DriverName and
+// DefaultCollation; JSON functions, sequence/RETURNING and sql_mode are
+// handled exactly as for MySQL.
+//
+// Unlike the other dialects, this one can't be selected by driver name:
+// MariaDB speaks the MySQL wire protocol and connects through the "mysql"
+// database/sql driver (there is no registered "mariadb" driver), so the
+// engine's driver name is always "mysql" whether it's actually talking to
+// MySQL or MariaDB. Callers that know which one they have (from
+// sqlutil.TestDB.Variant or the equivalent runtime config) must use
+// NewDialectForVariant instead of the driver-name-keyed NewDialect.
+type mariadbDialect struct {
+	mysqlDialect
+}
+
+// NewMariadbDialect returns the Dialect for an engine known to be
+// connected to MariaDB.
+func NewMariadbDialect(engine *xorm.Engine) Dialect {
+	d := mariadbDialect{}
+	d.engine = engine
+	d.dialect = d
+	return &d
+}
+
+func (db *mariadbDialect) DriverName() string {
+	return "mariadb"
+}
+
+// DefaultCollation returns MariaDB's default utf8mb4 collation, which
+// differs from the one MySQLTestDB/MySQL dialect use.
+func (db *mariadbDialect) DefaultCollation() string {
+	return "utf8mb4_general_ci"
+}
+
+// NewDialectForVariant returns NewMariadbDialect(engine) when variant is
+// "mariadb", and falls back to the normal driver-name-based NewDialect
+// for everything else (including ""). variant is expected to come from
+// sqlutil.TestDB.Variant or the matching field on the runtime DB config.
+func NewDialectForVariant(engine *xorm.Engine, variant string) Dialect {
+	if variant == "mariadb" {
+		return NewMariadbDialect(engine)
+	}
+	return NewDialect(engine)
+}
@@ -0,0 +1,29 @@
+package migrator
+
+import (
+	"testing"
+
+	"xorm.io/xorm"
+)
+
+// TestNewDialectForVariant locks in the one place in this package that
+// actually distinguishes MariaDB from plain MySQL: selection by variant
+// string rather than by driver name (both connect as "mysql").
+func TestNewDialectForVariant(t *testing.T) {
+	engine := &xorm.Engine{}
+
+	mariadb := NewDialectForVariant(engine, "mariadb")
+	if mariadb.DriverName() != "mariadb" {
+		t.Fatalf("expected variant %q to select the mariadb dialect, got driver %q", "mariadb", mariadb.DriverName())
+	}
+	if mariadb.DefaultCollation() != "utf8mb4_general_ci" {
+		t.Fatalf("expected mariadbDialect's own default collation, got %q", mariadb.DefaultCollation())
+	}
+
+	for _, variant := range []string{"", "mysql"} {
+		got := NewDialectForVariant(engine, variant)
+		if _, ok := got.(*mariadbDialect); ok {
+			t.Fatalf("expected variant %q to fall back to NewDialect, got a mariadbDialect", variant)
+		}
+	}
+}
@@ -21,6 +21,13 @@ type TestDB struct {
 	DriverName string
 	ConnStr    string
 	Path       string
+
+	// Variant distinguishes a database server from the database/sql driver
+	// used to talk to it - e.g. MariaDB speaks the MySQL wire protocol, so
+	// it connects with DriverName "mysql", but Variant "mariadb" tells the
+	// migrator which Dialect to use for it. Empty means "same as
+	// DriverName".
+	Variant string
 }
 
 func GetTestDBType() string {
@@ -37,6 +44,8 @@ func GetTestDB(t ITestDB, dbType string) (*TestDB, error) {
 	switch dbType {
 	case "mysql":
 		return MySQLTestDB()
+	case "mariadb":
+		return MariaDBTestDB()
 	case "postgres":
 		return PostgresTestDB()
 	case "sqlite3":
@@ -127,6 +136,30 @@ func MySQLTestDB() (*TestDB, error) {
 	}, nil
 }
 
+// MariaDBTestDB returns the connection details for the MariaDB test
+// database. DriverName stays "mysql" - MariaDB speaks the MySQL wire
+// protocol, and "mariadb" isn't a registered database/sql driver - but
+// Variant is "mariadb" so the migrator can select its own Dialect for
+// MariaDB. That dialect differs from MySQL's only in its driver name and
+// default collation; JSON functions, sequence/RETURNING and sql_mode are
+// handled as for MySQL.
+func MariaDBTestDB() (*TestDB, error) {
+	host := os.Getenv("MARIADB_HOST")
+	if host == "" {
+		host = "localhost"
+	}
+	port := os.Getenv("MARIADB_PORT")
+	if port == "" {
+		port = "3306"
+	}
+	conn_str := fmt.Sprintf("grafana:password@tcp(%s:%s)/grafana_tests?collation=utf8mb4_general_ci&sql_mode='ANSI_QUOTES'&parseTime=true", host, port)
+	return &TestDB{
+		DriverName: "mysql",
+		ConnStr:    conn_str,
+		Variant:    "mariadb",
+	}, nil
+}
+
 func PostgresTestDB() (*TestDB, error) {
 	host := os.Getenv("POSTGRES_HOST")
 	if host == "" {